@@ -0,0 +1,87 @@
+package lint
+
+import (
+	"bytes"
+	"html"
+	"strings"
+)
+
+// renderADoc is vale's built-in, dependency-free AsciiDoc renderer. Like
+// renderRST, it covers the common constructs -- titles (`=`/`==`/...),
+// paragraphs, bullet lists, and delimited code blocks (`----`) -- rather
+// than the full AsciiDoc spec. Call UseExecADoc to fall back to the
+// reference asciidoctor implementation for documents that need more.
+func renderADoc(b []byte) ([]byte, error) {
+	lines := strings.Split(string(b), "\n")
+
+	var out bytes.Buffer
+	var para []string
+	inListing := false
+
+	flushPara := func() {
+		if len(para) == 0 {
+			return
+		}
+		out.WriteString("<p>" + html.EscapeString(strings.Join(para, " ")) + "</p>\n")
+		para = nil
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "----" {
+			if inListing {
+				out.WriteString("</pre>\n")
+			} else {
+				flushPara()
+				out.WriteString("<pre>")
+			}
+			inListing = !inListing
+			continue
+		}
+
+		if inListing {
+			out.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		if trimmed == "" {
+			flushPara()
+			continue
+		}
+
+		if level, text, ok := adocHeading(trimmed); ok {
+			flushPara()
+			tag := []byte{'h', byte('0' + level)}
+			out.WriteString("<" + string(tag) + ">" + html.EscapeString(text) + "</" + string(tag) + ">\n")
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "* ") {
+			flushPara()
+			out.WriteString("<ul><li>" + html.EscapeString(strings.TrimPrefix(trimmed, "* ")) + "</li></ul>\n")
+			continue
+		}
+
+		para = append(para, trimmed)
+	}
+	flushPara()
+	if inListing {
+		out.WriteString("</pre>\n")
+	}
+
+	return out.Bytes(), nil
+}
+
+// adocHeading parses an AsciiDoc ATX-style title, e.g. "== Section", into
+// its level (1-based, so "=" is h1) and text.
+func adocHeading(line string) (level int, text string, ok bool) {
+	i := 0
+	for i < len(line) && line[i] == '=' {
+		i++
+	}
+	if i == 0 || i >= len(line) || line[i] != ' ' {
+		return 0, "", false
+	}
+	return i, strings.TrimSpace(line[i:]), true
+}
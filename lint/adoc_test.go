@@ -0,0 +1,39 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderADoc(t *testing.T) {
+	in := "= Title\n\nA paragraph.\n\n* one\n* two\n"
+
+	out, err := renderADoc([]byte(in))
+	if err != nil {
+		t.Fatalf("renderADoc returned an error: %v", err)
+	}
+
+	html := string(out)
+	for _, want := range []string{"<h1>Title</h1>", "<p>A paragraph.</p>", "<li>one</li>", "<li>two</li>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("renderADoc(%q) = %q, want it to contain %q", in, html, want)
+		}
+	}
+}
+
+func TestRenderADocListingBlock(t *testing.T) {
+	in := "----\ncode here\n----\n\nAfter.\n"
+
+	out, err := renderADoc([]byte(in))
+	if err != nil {
+		t.Fatalf("renderADoc returned an error: %v", err)
+	}
+
+	html := string(out)
+	if !strings.Contains(html, "<pre>") || !strings.Contains(html, "code here") {
+		t.Errorf("renderADoc(%q) = %q, want a <pre> block containing the listing text", in, html)
+	}
+	if !strings.Contains(html, "<p>After.</p>") {
+		t.Errorf("renderADoc(%q) = %q, want the trailing paragraph to render normally", in, html)
+	}
+}
@@ -0,0 +1,123 @@
+package lint
+
+import (
+	"go/ast"
+	"go/doc/comment"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/ValeLint/vale/core"
+)
+
+// urlRE and rfcRE match the spans go/doc/comment auto-links in rendered
+// GoDoc -- bare URLs and "RFC NNNN" references. We mask them out before
+// prose checks so they don't trip spelling or capitalization rules.
+var urlRE = regexp.MustCompile(`(?:https?|ftp)://\S+`)
+var rfcRE = regexp.MustCompile(`\bRFC\s+\d+\b`)
+
+func maskLinks(s string) string {
+	s = urlRE.ReplaceAllString(s, "URL")
+	s = rfcRE.ReplaceAllString(s, "RFC")
+	return s
+}
+
+// lintGoDoc treats the doc comments attached to a Go source file's package,
+// types, funcs, fields, and top-level vars/consts as prose, so that the
+// same style and grammar checks applied to Markdown or HTML apply to GoDoc
+// too.
+func (l Linter) lintGoDoc(f *core.File) {
+	b, err := ioutil.ReadFile(f.Path)
+	if !core.CheckError(err, f.Path) {
+		return
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, f.Path, b, parser.ParseComments)
+	if !core.CheckError(err, f.Path) {
+		return
+	}
+
+	ctx := core.PrepText(string(b))
+	lines := strings.Count(ctx, "\n") + 1
+	if file.Doc != nil {
+		l.lintGoComment(f, ctx, file.Doc, lines)
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		var doc *ast.CommentGroup
+		switch decl := n.(type) {
+		case *ast.GenDecl:
+			doc = decl.Doc
+		case *ast.FuncDecl:
+			doc = decl.Doc
+		case *ast.TypeSpec:
+			doc = decl.Doc
+		case *ast.ValueSpec:
+			doc = decl.Doc
+		case *ast.Field:
+			doc = decl.Doc
+		}
+		if doc != nil {
+			l.lintGoComment(f, ctx, doc, lines)
+		}
+		return true
+	})
+}
+
+// lintGoComment lints a single doc comment as prose. It routes the comment
+// through go/doc/comment so that preformatted blocks (indented code) are
+// skipped rather than linted as garbled English, and so that headings and
+// list items are each linted as their own unit. As in lintHTMLTokens, lines
+// is the line count of the file ctx was built from; ctx here is the whole
+// .go file, so no front-matter style offset is added.
+func (l Linter) lintGoComment(f *core.File, ctx string, doc *ast.CommentGroup, lines int) {
+	parsed := new(comment.Parser).Parse(doc.Text())
+	for _, block := range parsed.Content {
+		l.lintGoDocBlock(f, ctx, block, lines)
+	}
+}
+
+func (l Linter) lintGoDocBlock(f *core.File, ctx string, block comment.Block, lines int) {
+	switch b := block.(type) {
+	case *comment.Paragraph:
+		l.lintGoDocText(f, ctx, b.Text, lines)
+	case *comment.Heading:
+		l.lintGoDocText(f, ctx, b.Text, lines)
+	case *comment.List:
+		for _, item := range b.Items {
+			for _, sub := range item.Content {
+				l.lintGoDocBlock(f, ctx, sub, lines)
+			}
+		}
+	case *comment.Code:
+		// Preformatted code isn't prose -- skip it.
+	}
+}
+
+func (l Linter) lintGoDocText(f *core.File, ctx string, spans []comment.Text, lines int) {
+	txt := maskLinks(textOf(spans))
+	if strings.TrimSpace(txt) != "" {
+		l.lintProse(f, ctx, txt, lines, 0)
+	}
+}
+
+// textOf flattens a slice of comment.Text spans back into plain text.
+func textOf(spans []comment.Text) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		switch s := span.(type) {
+		case comment.Plain:
+			sb.WriteString(string(s))
+		case comment.Italic:
+			sb.WriteString(string(s))
+		case *comment.Link:
+			sb.WriteString(textOf(s.Text))
+		case *comment.DocLink:
+			sb.WriteString(textOf(s.Text))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,16 @@
+package lint
+
+import "testing"
+
+func TestMaskLinks(t *testing.T) {
+	cases := map[string]string{
+		"See https://example.com/docs for more.": "See URL for more.",
+		"Defined in RFC 7231, section 4.":        "Defined in RFC, section 4.",
+		"Nothing to mask here.":                  "Nothing to mask here.",
+	}
+	for in, want := range cases {
+		if got := maskLinks(in); got != want {
+			t.Errorf("maskLinks(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
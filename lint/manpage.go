@@ -0,0 +1,48 @@
+package lint
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os/exec"
+
+	"github.com/ValeLint/vale/core"
+)
+
+// manArgs renders roff/mdoc manpages to plain UTF-8 text via mandoc, which
+// understands both the classic `man` macros and the newer `mdoc` ones.
+var manArgs = []string{"-Tutf8", "-mandoc"}
+
+// manRenderer defaults to the built-in renderRoff; call UseExecMan to fall
+// back to mandoc for documents that need more than our macro dispatch
+// covers.
+var manRenderer markupRenderer = renderRoff
+
+// UseExecMan switches manpage rendering from the built-in renderer to
+// mandoc, invoked at the given path.
+func UseExecMan(mandoc string) {
+	manRenderer = execMan(mandoc)
+}
+
+func execMan(mandoc string) markupRenderer {
+	return func(b []byte) ([]byte, error) {
+		var out bytes.Buffer
+		cmd := exec.Command(mandoc, manArgs...)
+		cmd.Stdin = bytes.NewReader(b)
+		cmd.Stdout = &out
+		if err := cmd.Run(); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	}
+}
+
+func (l Linter) lintManpage(f *core.File) {
+	b, err := ioutil.ReadFile(f.Path)
+	if !core.CheckError(err, f.Path) {
+		return
+	}
+	out, err := manRenderer(b)
+	if core.CheckError(err, f.Path) {
+		l.lintHTMLTokens(f, b, out, 0)
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"strings"
 
 	"github.com/ValeLint/vale/core"
-	"github.com/russross/blackfriday"
+	"github.com/russross/blackfriday/v2"
 	"golang.org/x/net/html"
 )
 
@@ -37,19 +37,117 @@ var adocArgs = []string{
 }
 
 // Blackfriday configuration.
-var commonHTMLFlags = 0 | blackfriday.HTML_USE_XHTML
-var commonExtensions = 0 |
-	blackfriday.EXTENSION_NO_INTRA_EMPHASIS |
-	blackfriday.EXTENSION_TABLES |
-	blackfriday.EXTENSION_FENCED_CODE
-var renderer = blackfriday.HtmlRenderer(commonHTMLFlags, "", "")
-var options = blackfriday.Options{Extensions: commonExtensions}
+//
+// MarkdownExtensions lists additional blackfriday extensions (by name) to
+// enable on top of the defaults below -- see `extensionFlags` for the
+// supported names. This lets a vale config turn on extensions like
+// strikethrough or definition lists without a code change.
+var MarkdownExtensions []string
+
+var commonHTMLFlags = blackfriday.UseXHTML
+
+var commonExtensions = blackfriday.CommonExtensions |
+	blackfriday.Footnotes |
+	blackfriday.AutoHeadingIDs
+
+// extensionFlags maps the extension names accepted in a vale config to
+// their blackfriday v2 flags.
+var extensionFlags = map[string]blackfriday.Extensions{
+	"strikethrough":    blackfriday.Strikethrough,
+	"definition-lists": blackfriday.DefinitionLists,
+	"autolink":         blackfriday.Autolink,
+	"footnotes":        blackfriday.Footnotes,
+	"smartypants":      blackfriday.Smartypants,
+	"hard-line-break":  blackfriday.HardLineBreak,
+}
+
+// markdownExtensions combines the default blackfriday extensions with
+// whatever's been opted into via MarkdownExtensions.
+//
+// Out of scope for now: a pluggable renderer choice (HTML vs. a custom
+// walker emitting vale's own block types) that would feed fenced code
+// blocks' language tag to syntax-aware checks. lintHTMLTokens currently
+// skips <code> content outright (see skipTags), and this package has no
+// syntax-aware checks to feed -- adding the hook without a consumer would
+// just be dead plumbing. Revisit once such a check exists.
+func markdownExtensions() blackfriday.Extensions {
+	exts := commonExtensions
+	for _, name := range MarkdownExtensions {
+		if flag, ok := extensionFlags[name]; ok {
+			exts |= flag
+		}
+	}
+	return exts
+}
 
 // HTML configuration.
 var heading = regexp.MustCompile(`^h\d$`)
 var skipTags = []string{"script", "style", "pre", "code", "tt"}
 var skipClasses = []string{}
 
+// frontMatterRE matches the YAML (`---`), TOML (`+++`), or JSON (`{`...`}`)
+// front matter block that Hugo, Jekyll, and other static site generators
+// place at the top of a Markdown file. It isn't Markdown, so we strip it
+// before rendering.
+var frontMatterRE = regexp.MustCompile(`(?s)\A(?:---\n.*?\n---|\+\+\+\n.*?\n\+\+\+|\{\n.*?\n\})\n?`)
+
+// shortcodeRE matches Hugo-style shortcodes, e.g. `{{< figure src="..."
+// caption="..." >}}` or `{{% note title="..." %}}`, capturing the
+// shortcode's name (with a leading `/` for a paired shortcode's closing
+// tag, e.g. `{{% /note %}}`) and its argument list. These aren't valid
+// Markdown and would otherwise be linted as garbled prose.
+var shortcodeRE = regexp.MustCompile(`(?s)\{\{[%<]\s*(/?\w[\w-]*)(.*?)[%>]\}\}`)
+
+// shortcodeAttrRE matches a quoted `key="value"` shortcode argument.
+var shortcodeAttrRE = regexp.MustCompile(`(\w[\w-]*)\s*=\s*"([^"]*)"`)
+
+// shortcodeAttrs lists, per shortcode name, which of its attributes hold
+// prose worth linting (e.g. a figure's caption) rather than machine-facing
+// values (e.g. a src path). RegisterShortcode adds to this on top of the
+// common Hugo shortcodes covered by default.
+var shortcodeAttrs = map[string][]string{
+	"figure": {"caption", "alt", "title"},
+	"note":   {"title"},
+}
+
+// RegisterShortcode teaches vale about a site-specific shortcode, so that
+// its named attributes are linted as prose instead of being discarded
+// along with the rest of the shortcode syntax.
+func RegisterShortcode(name string, proseAttrs []string) {
+	shortcodeAttrs[name] = proseAttrs
+}
+
+// stripFrontMatter removes a leading front matter block and reports how
+// many lines were removed, so callers can keep line numbers in sync with
+// the original file.
+func stripFrontMatter(b []byte) ([]byte, int) {
+	loc := frontMatterRE.FindIndex(b)
+	if loc == nil {
+		return b, 0
+	}
+	return b[loc[1]:], bytes.Count(b[:loc[1]], []byte("\n"))
+}
+
+// extractShortcodes blanks out shortcodes -- preserving their newlines so
+// that line numbers in the surrounding prose don't shift -- while linting
+// any attributes registered for that shortcode (see shortcodeAttrs) as
+// prose in their own right.
+func (l Linter) extractShortcodes(f *core.File, ctx string, lines int, b []byte) []byte {
+	return shortcodeRE.ReplaceAllFunc(b, func(m []byte) []byte {
+		groups := shortcodeRE.FindSubmatch(m)
+		name, args := strings.TrimPrefix(string(groups[1]), "/"), groups[2]
+		if proseAttrs, ok := shortcodeAttrs[name]; ok {
+			for _, attr := range shortcodeAttrRE.FindAllSubmatch(args, -1) {
+				key, val := string(attr[1]), string(attr[2])
+				if val != "" && core.StringInSlice(key, proseAttrs) {
+					l.lintProse(f, ctx, val, lines, 0)
+				}
+			}
+		}
+		return bytes.Repeat([]byte("\n"), bytes.Count(m, []byte("\n")))
+	})
+}
+
 func (l Linter) lintHTMLTokens(f *core.File, rawBytes []byte, fBytes []byte, offset int) {
 	var txt, attr, tag string
 	var tokt html.TokenType
@@ -136,33 +234,92 @@ func (l Linter) lintMarkdown(f *core.File) {
 	if !core.CheckError(err, f.Path) {
 		return
 	}
-	l.lintHTMLTokens(f, b, blackfriday.MarkdownOptions(b, renderer, options), 0)
+	b, offset := stripFrontMatter(b)
+
+	ctx := core.PrepText(string(b))
+	lines := strings.Count(ctx, "\n") + 1 + offset
+	b = l.extractShortcodes(f, ctx, lines, b)
+
+	// Allocated per file, not shared: with AutoHeadingIDs set, the HTML
+	// renderer dedupes heading IDs (and tracks output length) in state that
+	// lives on the renderer itself, so reusing one instance across files
+	// would leak that state between documents -- and race under
+	// concurrent linting.
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: commonHTMLFlags,
+	})
+	out := blackfriday.Run(b, blackfriday.WithRenderer(renderer), blackfriday.WithExtensions(markdownExtensions()))
+
+	l.lintHTMLTokens(f, b, out, offset)
+}
+
+// rstRenderer and adocRenderer convert a raw document into HTML. Both
+// default to vale's built-in pure-Go renderers (renderRST/renderADoc); call
+// UseExecRST/UseExecADoc to fall back to shelling out to the reference
+// rst2html/asciidoctor implementations for documents that need more than
+// our renderers support.
+type markupRenderer func(b []byte) ([]byte, error)
+
+var rstRenderer markupRenderer = renderRST
+var adocRenderer markupRenderer = renderADoc
+
+// UseExecRST switches RST rendering from the built-in renderer to the
+// reference rst2html implementation, invoked via the given Python
+// interpreter and rst2html script path.
+func UseExecRST(python string, rst2html string) {
+	rstRenderer = execRST(python, rst2html)
+}
+
+// UseExecADoc switches AsciiDoc rendering from the built-in renderer to
+// the reference asciidoctor implementation.
+func UseExecADoc(asciidoctor string) {
+	adocRenderer = execADoc(asciidoctor)
+}
+
+func execRST(python string, rst2html string) markupRenderer {
+	return func(b []byte) ([]byte, error) {
+		var out bytes.Buffer
+		cmd := exec.Command(python, append([]string{rst2html}, rstArgs...)...)
+		cmd.Stdin = bytes.NewReader(reCodeBlock.ReplaceAll(b, []byte("::")))
+		cmd.Stdout = &out
+		if err := cmd.Run(); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	}
+}
+
+func execADoc(asciidoctor string) markupRenderer {
+	return func(b []byte) ([]byte, error) {
+		var out bytes.Buffer
+		cmd := exec.Command(asciidoctor, adocArgs...)
+		cmd.Stdin = bytes.NewReader(b)
+		cmd.Stdout = &out
+		if err := cmd.Run(); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	}
 }
 
-func (l Linter) lintRST(f *core.File, python string, rst2html string) {
-	var out bytes.Buffer
+func (l Linter) lintRST(f *core.File) {
 	b, err := ioutil.ReadFile(f.Path)
 	if !core.CheckError(err, f.Path) {
 		return
 	}
-	cmd := exec.Command(python, append([]string{rst2html}, rstArgs...)...)
-	cmd.Stdin = bytes.NewReader(reCodeBlock.ReplaceAll(b, []byte("::")))
-	cmd.Stdout = &out
-	if core.CheckError(cmd.Run(), f.Path) {
-		l.lintHTMLTokens(f, b, out.Bytes(), 0)
+	out, err := rstRenderer(b)
+	if core.CheckError(err, f.Path) {
+		l.lintHTMLTokens(f, b, out, 0)
 	}
 }
 
-func (l Linter) lintADoc(f *core.File, asciidoctor string) {
-	var out bytes.Buffer
+func (l Linter) lintADoc(f *core.File) {
 	b, err := ioutil.ReadFile(f.Path)
 	if !core.CheckError(err, f.Path) {
 		return
 	}
-	cmd := exec.Command(asciidoctor, adocArgs...)
-	cmd.Stdin = bytes.NewReader(b)
-	cmd.Stdout = &out
-	if core.CheckError(cmd.Run(), f.Path) {
-		l.lintHTMLTokens(f, b, out.Bytes(), 0)
+	out, err := adocRenderer(b)
+	if core.CheckError(err, f.Path) {
+		l.lintHTMLTokens(f, b, out, 0)
 	}
 }
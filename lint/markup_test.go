@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ValeLint/vale/core"
+)
+
+func TestStripFrontMatter(t *testing.T) {
+	cases := map[string]struct {
+		in     string
+		want   string
+		offset int
+	}{
+		"yaml": {"---\ntitle: Hi\n---\nBody.\n", "Body.\n", 3},
+		"toml": {"+++\ntitle = \"Hi\"\n+++\nBody.\n", "Body.\n", 3},
+		"json": {"{\n  \"title\": \"Hi\"\n}\nBody.\n", "Body.\n", 3},
+		"none": {"Body.\n", "Body.\n", 0},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, offset := stripFrontMatter([]byte(c.in))
+			if !bytes.Equal(got, []byte(c.want)) {
+				t.Errorf("stripFrontMatter(%q) body = %q, want %q", c.in, got, c.want)
+			}
+			if offset != c.offset {
+				t.Errorf("stripFrontMatter(%q) offset = %d, want %d", c.in, offset, c.offset)
+			}
+		})
+	}
+}
+
+func TestShortcodeRE(t *testing.T) {
+	m := shortcodeRE.FindSubmatch([]byte(`{{< figure src="a.png" caption="A cat." >}}`))
+	if m == nil {
+		t.Fatal("shortcodeRE didn't match a figure shortcode")
+	}
+	if name := string(m[1]); name != "figure" {
+		t.Errorf("shortcode name = %q, want %q", name, "figure")
+	}
+
+	attrs := shortcodeAttrRE.FindAllSubmatch(m[2], -1)
+	got := map[string]string{}
+	for _, a := range attrs {
+		got[string(a[1])] = string(a[2])
+	}
+	want := map[string]string{"src": "a.png", "caption": "A cat."}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("attr %q = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestExtractShortcodesPairedClosingTag(t *testing.T) {
+	var l Linter
+	in := []byte("{{% note %}}Body.{{% /note %}}")
+
+	out := l.extractShortcodes(&core.File{}, "", 0, in)
+
+	if bytes.Contains(out, []byte("{{%")) || bytes.Contains(out, []byte("%}}")) {
+		t.Errorf("extractShortcodes(%q) = %q, want both the opening and closing tags blanked", in, out)
+	}
+	if !bytes.Contains(out, []byte("Body.")) {
+		t.Errorf("extractShortcodes(%q) = %q, want the body text preserved", in, out)
+	}
+}
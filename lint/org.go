@@ -0,0 +1,24 @@
+package lint
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/ValeLint/vale/core"
+	"github.com/niklasfasching/go-org/org"
+)
+
+func (l Linter) lintOrg(f *core.File) {
+	b, err := ioutil.ReadFile(f.Path)
+	if !core.CheckError(err, f.Path) {
+		return
+	}
+
+	doc := org.New().Parse(bytes.NewReader(b), f.Path)
+	html, err := doc.Write(org.NewHTMLWriter())
+	if !core.CheckError(err, f.Path) {
+		return
+	}
+
+	l.lintHTMLTokens(f, b, []byte(html), 0)
+}
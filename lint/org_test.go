@@ -0,0 +1,26 @@
+package lint
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ValeLint/vale/core"
+)
+
+func TestLintOrg(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "example.org")
+	in := "* Heading\n\nA paragraph.\n"
+	if err := ioutil.WriteFile(path, []byte(in), 0o644); err != nil {
+		t.Fatalf("failed to write test fixture: %v", err)
+	}
+
+	var l Linter
+	f := &core.File{Path: path, RealExt: ".org"}
+
+	// lintOrg shouldn't error or panic on a well-formed Org document; the
+	// actual prose/heading checks it feeds into are exercised via the
+	// shared lintHTMLTokens path tested elsewhere in this package.
+	l.lintOrg(f)
+}
@@ -0,0 +1,107 @@
+package lint
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// roffFontRE matches troff font-change escapes like \fB, \fI, \fR, \f[foo],
+// and \fP, which don't carry meaningful text of their own.
+var roffFontRE = regexp.MustCompile(`\\f(?:\[[^\]]*\]|.)`)
+
+// renderRoff is vale's built-in, dependency-free roff/mdoc renderer. It
+// turns the macros that structure a manpage -- `.SH`/`.SS` section
+// headings and `.IP`/`.TP`/`.RS` list-like entries -- into the same HTML
+// lintHTMLTokens already knows how to walk, and strips font-change escapes
+// (`\fB`, `\fI`, ...) from the running text. Call UseExecMan to fall back
+// to mandoc for documents that need full roff/mdoc support.
+func renderRoff(b []byte) ([]byte, error) {
+	lines := strings.Split(string(b), "\n")
+
+	var out bytes.Buffer
+	var para []string
+	inList := false
+	// inItem is true while accumulating the body text of a .IP/.TP/.RS
+	// entry, which (unlike an ordinary paragraph) follows the macro on
+	// the lines after it rather than inline with it.
+	inItem := false
+
+	flush := func() {
+		if len(para) == 0 {
+			return
+		}
+		text := html.EscapeString(strings.Join(para, " "))
+		if inItem {
+			out.WriteString("<li>" + text + "</li>\n")
+		} else {
+			out.WriteString("<p>" + text + "</p>\n")
+		}
+		para = nil
+	}
+	closeList := func() {
+		flush()
+		if inList {
+			out.WriteString("</ul>\n")
+			inList = false
+		}
+		inItem = false
+	}
+
+	for _, line := range lines {
+		if !strings.HasPrefix(line, ".") && !strings.HasPrefix(line, "'") {
+			if text := strings.TrimSpace(stripRoffEscapes(line)); text != "" {
+				para = append(para, text)
+			} else {
+				flush()
+			}
+			continue
+		}
+
+		macro, rest := roffMacro(line)
+		switch macro {
+		case ".SH":
+			closeList()
+			out.WriteString("<h1>" + html.EscapeString(stripRoffEscapes(rest)) + "</h1>\n")
+		case ".SS":
+			closeList()
+			out.WriteString("<h2>" + html.EscapeString(stripRoffEscapes(rest)) + "</h2>\n")
+		case ".IP", ".TP", ".RS":
+			flush()
+			if !inList {
+				out.WriteString("<ul>\n")
+				inList = true
+			}
+			inItem = true
+			if text := strings.TrimSpace(stripRoffEscapes(rest)); text != "" {
+				para = append(para, text)
+			}
+		case ".RE", ".LP", ".PP", ".P":
+			closeList()
+		default:
+			// Unrecognized requests (.TH, .B, font calls, etc.) don't
+			// contribute prose on their own.
+		}
+	}
+	closeList()
+
+	return out.Bytes(), nil
+}
+
+// roffMacro splits a roff request line, e.g. ".SH NAME", into its macro
+// (".SH") and the rest of the line ("NAME").
+func roffMacro(line string) (macro string, rest string) {
+	fields := strings.SplitN(line, " ", 2)
+	macro = fields[0]
+	if len(fields) > 1 {
+		rest = fields[1]
+	}
+	return macro, rest
+}
+
+// stripRoffEscapes removes troff font-change escapes so `\fBbold\fR` reads
+// as plain "bold" prose.
+func stripRoffEscapes(s string) string {
+	return roffFontRE.ReplaceAllString(s, "")
+}
@@ -0,0 +1,29 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderRoff(t *testing.T) {
+	in := ".TH FOO 1\n.SH NAME\nfoo \\- does a thing\n.SH DESCRIPTION\nA \\fBbold\\fR paragraph.\n.TP\nfirst item\n.TP\nsecond item\n"
+
+	out, err := renderRoff([]byte(in))
+	if err != nil {
+		t.Fatalf("renderRoff returned an error: %v", err)
+	}
+
+	html := string(out)
+	for _, want := range []string{
+		"<h1>NAME</h1>",
+		"<h1>DESCRIPTION</h1>",
+		"<p>foo \\- does a thing</p>",
+		"<p>A bold paragraph.</p>",
+		"<li>first item</li>",
+		"<li>second item</li>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("renderRoff(%q) = %q, want it to contain %q", in, html, want)
+		}
+	}
+}
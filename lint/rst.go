@@ -0,0 +1,114 @@
+package lint
+
+import (
+	"bytes"
+	"html"
+	"strings"
+)
+
+// rstAdornment lists the characters Docutils recognizes as section-title
+// adornment, ordered from outermost (title) to innermost. A document won't
+// necessarily use all of them, or in this order, but reserving one `<hN>`
+// level per adornment character encountered keeps nesting consistent
+// within a single file.
+const rstAdornment = "=-`:'\"~^_*+#<>."
+
+// renderRST is vale's built-in, dependency-free reStructuredText renderer.
+// It covers the subset of RST that shows up in the kind of prose vale
+// lints -- titles, paragraphs, bullet lists, and literal blocks -- rather
+// than the full Docutils spec. Call UseExecRST to fall back to the
+// reference rst2html implementation for documents that need more.
+func renderRST(b []byte) ([]byte, error) {
+	b = reCodeBlock.ReplaceAll(b, []byte("::"))
+	lines := strings.Split(string(b), "\n")
+
+	var out bytes.Buffer
+	var para []string
+	var levels []byte
+	inLiteral := false
+
+	flushPara := func() {
+		if len(para) == 0 {
+			return
+		}
+		out.WriteString("<p>" + html.EscapeString(strings.Join(para, " ")) + "</p>\n")
+		para = nil
+	}
+
+	headingLevel := func(adornment byte) int {
+		for i, c := range levels {
+			if c == adornment {
+				return i + 1
+			}
+		}
+		levels = append(levels, adornment)
+		return len(levels)
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if inLiteral {
+			if trimmed == "" && (i+1 >= len(lines) || !strings.HasPrefix(lines[i+1], " ")) {
+				inLiteral = false
+				out.WriteString("</pre>\n")
+				continue
+			}
+			out.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		if trimmed == "" {
+			flushPara()
+			continue
+		}
+
+		if isRSTAdornment(trimmed) && len(para) == 1 {
+			level := headingLevel(trimmed[0])
+			tag := []byte{'h', byte('0' + level)}
+			out.WriteString("<" + string(tag) + ">" + html.EscapeString(para[0]) + "</" + string(tag) + ">\n")
+			para = nil
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "* ") || strings.HasPrefix(trimmed, "- ") {
+			flushPara()
+			out.WriteString("<ul><li>" + html.EscapeString(trimmed[2:]) + "</li></ul>\n")
+			continue
+		}
+
+		if strings.HasSuffix(trimmed, "::") {
+			flushPara()
+			text := strings.TrimSuffix(trimmed, "::")
+			if text != "" {
+				out.WriteString("<p>" + html.EscapeString(text) + ":</p>\n")
+			}
+			out.WriteString("<pre>")
+			inLiteral = true
+			continue
+		}
+
+		para = append(para, trimmed)
+	}
+	flushPara()
+	if inLiteral {
+		out.WriteString("</pre>\n")
+	}
+
+	return out.Bytes(), nil
+}
+
+// isRSTAdornment reports whether s is a run of a single RST section-title
+// adornment character, e.g. "========" or "----".
+func isRSTAdornment(s string) bool {
+	if s == "" || !strings.ContainsRune(rstAdornment, rune(s[0])) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] != s[0] {
+			return false
+		}
+	}
+	return true
+}
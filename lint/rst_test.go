@@ -0,0 +1,39 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderRST(t *testing.T) {
+	in := "Title\n=====\n\nA paragraph.\n\n* one\n* two\n"
+
+	out, err := renderRST([]byte(in))
+	if err != nil {
+		t.Fatalf("renderRST returned an error: %v", err)
+	}
+
+	html := string(out)
+	for _, want := range []string{"<h1>Title</h1>", "<p>A paragraph.</p>", "<li>one</li>", "<li>two</li>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("renderRST(%q) = %q, want it to contain %q", in, html, want)
+		}
+	}
+}
+
+func TestRenderRSTLiteralBlock(t *testing.T) {
+	in := "Example::\n\n    code here\n\nAfter.\n"
+
+	out, err := renderRST([]byte(in))
+	if err != nil {
+		t.Fatalf("renderRST returned an error: %v", err)
+	}
+
+	html := string(out)
+	if !strings.Contains(html, "<pre>") || !strings.Contains(html, "code here") {
+		t.Errorf("renderRST(%q) = %q, want a <pre> block containing the literal text", in, html)
+	}
+	if !strings.Contains(html, "<p>After.</p>") {
+		t.Errorf("renderRST(%q) = %q, want the trailing paragraph to render normally", in, html)
+	}
+}